@@ -0,0 +1,241 @@
+package orion
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	newrelic "github.com/newrelic/go-agent"
+)
+
+//NewConfig returns a newrelic.Config populated with Orion's defaults on top
+//of newrelic.NewConfig(appname, license). Orion defaults to the W3C
+//Distributed Tracing model rather than Cross Application Tracing: CAT and DT
+//cannot be enabled simultaneously, and newrelic.Config.Validate will return
+//errMixedTracers if a caller flips CrossApplicationTracer back on without
+//also disabling DistributedTracer.
+func NewConfig(appname, license string) newrelic.Config {
+	c := newrelic.NewConfig(appname, license)
+	c.CrossApplicationTracer.Enabled = false
+	c.DistributedTracer.Enabled = true
+	return c
+}
+
+//LoadNewRelicConfig builds a newrelic.Config from NewConfig(appname, license)
+//and overlays any recognized NEW_RELIC_* environment variables on top of it,
+//so deployments can tune the agent without a code change. Variables that are
+//unset are left at Orion's defaults. Precedence across all config sources is
+//server-side overrides (see NewConfigPoller) > environment variables > code
+//defaults.
+func LoadNewRelicConfig() newrelic.Config {
+	c := NewConfig(envOr("NEW_RELIC_APP_NAME", ""), envOr("NEW_RELIC_LICENSE_KEY", ""))
+
+	if v, ok := os.LookupEnv("NEW_RELIC_HIGH_SECURITY"); ok {
+		c.HighSecurity = envBool(v)
+	}
+	if v, ok := os.LookupEnv("NEW_RELIC_DISTRIBUTED_TRACING_ENABLED"); ok {
+		c.DistributedTracer.Enabled = envBool(v)
+		c.CrossApplicationTracer.Enabled = !c.DistributedTracer.Enabled
+	}
+	if v, ok := os.LookupEnv("NEW_RELIC_TRANSACTION_TRACER_THRESHOLD"); ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.TransactionTracer.Threshold.IsApdexFailing = false
+			c.TransactionTracer.Threshold.Duration = d
+		}
+	}
+	if v, ok := os.LookupEnv("NEW_RELIC_ERROR_COLLECTOR_IGNORE_STATUS_CODES"); ok {
+		c.ErrorCollector.IgnoreStatusCodes = parseIntList(v)
+	}
+	if v, ok := os.LookupEnv("NEW_RELIC_LABELS"); ok {
+		c.Labels = parseLabels(v)
+	}
+
+	return c
+}
+
+//ConfigChangeListener is invoked by ConfigPoller.Poll whenever applying a
+//server-side override changes the effective Config. old is the Config
+//before the change.
+type ConfigChangeListener func(old, new newrelic.Config)
+
+//ConfigPoller periodically fetches the subset of settings New Relic allows
+//to be server-controlled and overlays them on top of a local newrelic.Config,
+//notifying registered listeners whenever the effective Config changes.
+//Server-side settings always take precedence over the local Config, which is
+//itself expected to already have environment overrides applied via
+//LoadNewRelicConfig. A ConfigPoller is safe for concurrent use: Poll is meant
+//to be driven by a single background ticker goroutine while Config/OnChange
+//are called from request-handling goroutines.
+type ConfigPoller struct {
+	//Fetch returns the server-side overrides to apply, keyed the same way
+	//New Relic's server-side config payload is: e.g.
+	//"transaction_tracer.enabled", "error_collector.ignore_status_codes".
+	Fetch func() (map[string]interface{}, error)
+
+	mu        sync.RWMutex
+	listeners []ConfigChangeListener
+	current   newrelic.Config
+}
+
+//NewConfigPoller creates a ConfigPoller that overlays server-side overrides
+//on top of base.
+func NewConfigPoller(base newrelic.Config, fetch func() (map[string]interface{}, error)) *ConfigPoller {
+	return &ConfigPoller{Fetch: fetch, current: base}
+}
+
+//OnChange registers a listener invoked every time Poll applies a change.
+func (p *ConfigPoller) OnChange(l ConfigChangeListener) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listeners = append(p.listeners, l)
+}
+
+//Config returns the current effective Config.
+func (p *ConfigPoller) Config() newrelic.Config {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.current
+}
+
+//Poll fetches the latest server-side overrides and applies them to the
+//current Config, notifying listeners only if the effective Config actually
+//changed. It is meant to be called on a ticker by the caller; ConfigPoller
+//does not manage its own goroutine.
+func (p *ConfigPoller) Poll() error {
+	overrides, err := p.Fetch()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	old := p.current
+	next := old
+	applyServerSideOverrides(&next, overrides)
+	p.current = next
+	changed := !reflect.DeepEqual(old, next)
+	listeners := p.listeners
+	p.mu.Unlock()
+
+	if !changed {
+		return nil
+	}
+	for _, l := range listeners {
+		l(old, next)
+	}
+	return nil
+}
+
+//applyServerSideOverrides overlays overrides on top of c. overrides comes
+//from decoding a JSON server-side config payload, so a JSON array such as
+//"error_collector.ignore_status_codes" decodes to []interface{} holding
+//float64 elements rather than []int, and must be converted accordingly.
+func applyServerSideOverrides(c *newrelic.Config, overrides map[string]interface{}) {
+	if v, ok := overrides["transaction_tracer.enabled"].(bool); ok {
+		c.TransactionTracer.Enabled = v
+	}
+	if v, ok := overrides["error_collector.enabled"].(bool); ok {
+		c.ErrorCollector.Enabled = v
+	}
+	if v, ok := overrides["error_collector.ignore_status_codes"]; ok {
+		if codes, ok := toIntSlice(v); ok {
+			c.ErrorCollector.IgnoreStatusCodes = codes
+		}
+	}
+}
+
+//toIntSlice converts the numeric-array shapes JSON decoding can produce
+//([]interface{} of float64 or json.Number, or already []int for callers
+//that pre-convert) into []int.
+func toIntSlice(v interface{}) ([]int, bool) {
+	switch vv := v.(type) {
+	case []int:
+		return vv, true
+	case []interface{}:
+		out := make([]int, 0, len(vv))
+		for _, elem := range vv {
+			switch n := elem.(type) {
+			case float64:
+				out = append(out, int(n))
+			case json.Number:
+				i, err := n.Int64()
+				if err != nil {
+					return nil, false
+				}
+				out = append(out, int(i))
+			default:
+				return nil, false
+			}
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+//KubernetesAttributes collects NEW_RELIC_METADATA_* and
+//KUBERNETES_SERVICE_HOST into a flat map of k8s.* keys. This tree has no
+//transaction-attribute API for Orion to call this automatically, so it is a
+//caller-invoked helper: a handlers.Tracer implementation (or other startup
+//code) is expected to call KubernetesAttributes() and merge the result into
+//its own transaction attributes once that attribute path exists.
+func KubernetesAttributes() map[string]string {
+	attrs := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "NEW_RELIC_METADATA_") {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		key := strings.ToLower(strings.TrimPrefix(parts[0], "NEW_RELIC_METADATA_"))
+		attrs["k8s."+key] = parts[1]
+	}
+	if host := os.Getenv("KUBERNETES_SERVICE_HOST"); host != "" {
+		attrs["k8s.service_host"] = host
+	}
+	return attrs
+}
+
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
+
+func envBool(v string) bool {
+	b, _ := strconv.ParseBool(v)
+	return b
+}
+
+func parseIntList(v string) []int {
+	var out []int
+	for _, s := range strings.Split(v, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(s); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func parseLabels(v string) map[string]string {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(v, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		labels[kv[0]] = kv[1]
+	}
+	return labels
+}
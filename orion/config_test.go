@@ -0,0 +1,85 @@
+package orion
+
+import (
+	"encoding/json"
+	"testing"
+
+	newrelic "github.com/newrelic/go-agent"
+)
+
+func TestParseIntList(t *testing.T) {
+	got := parseIntList(" 404, 500 ,, 409")
+	want := []int{404, 500, 409}
+	if len(got) != len(want) {
+		t.Fatalf("parseIntList() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseIntList() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	got := parseLabels("env:prod;team:payments; ;malformed")
+	want := map[string]string{"env": "prod", "team": "payments"}
+	if len(got) != len(want) {
+		t.Fatalf("parseLabels() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("parseLabels()[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestEnvBool(t *testing.T) {
+	cases := map[string]bool{"true": true, "1": true, "false": false, "": false, "not-a-bool": false}
+	for in, want := range cases {
+		if got := envBool(in); got != want {
+			t.Errorf("envBool(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestApplyServerSideOverridesDecodesJSONNumbers(t *testing.T) {
+	var overrides map[string]interface{}
+	payload := []byte(`{"error_collector.ignore_status_codes":[404,500]}`)
+	if err := json.Unmarshal(payload, &overrides); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	c := newrelic.NewConfig("app", "")
+	applyServerSideOverrides(&c, overrides)
+
+	want := []int{404, 500}
+	got := c.ErrorCollector.IgnoreStatusCodes
+	if len(got) != len(want) {
+		t.Fatalf("IgnoreStatusCodes = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("IgnoreStatusCodes = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestConfigPollerOnlyNotifiesOnChange(t *testing.T) {
+	fired := 0
+	p := NewConfigPoller(newrelic.NewConfig("app", ""), func() (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	})
+	p.OnChange(func(old, next newrelic.Config) {
+		fired++
+	})
+
+	for i := 0; i < 5; i++ {
+		if err := p.Poll(); err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+	}
+
+	if fired != 0 {
+		t.Fatalf("listener fired %d times for no-op polls, want 0", fired)
+	}
+}
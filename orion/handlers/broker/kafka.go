@@ -0,0 +1,80 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"github.com/carousell/Orion/orion/handlers"
+)
+
+//KafkaBroker adapts a sarama.Client/SyncProducer pair to
+//handlers.MessageBroker. It consumes every partition of a topic from the
+//newest offset via sarama.Consumer; deployments needing consumer-group
+//rebalancing and committed offsets should build on sarama.ConsumerGroup
+//instead.
+type KafkaBroker struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+}
+
+//NewKafkaBroker wraps client/producer as a handlers.MessageBroker.
+func NewKafkaBroker(client sarama.Client, producer sarama.SyncProducer) *KafkaBroker {
+	return &KafkaBroker{client: client, producer: producer}
+}
+
+//System reports "kafka" as the messaging.system transaction attribute.
+func (b *KafkaBroker) System() string {
+	return "kafka"
+}
+
+//Subscribe consumes every partition of the given Kafka topic from the
+//newest offset, invoking handler for each message on its own goroutine per
+//partition.
+func (b *KafkaBroker) Subscribe(destination string, handler func(ctx context.Context, msg handlers.Message)) error {
+	consumer, err := sarama.NewConsumerFromClient(b.client)
+	if err != nil {
+		return err
+	}
+	partitions, err := consumer.Partitions(destination)
+	if err != nil {
+		return err
+	}
+	for _, partition := range partitions {
+		pc, err := consumer.ConsumePartition(destination, partition, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		go func(pc sarama.PartitionConsumer) {
+			for m := range pc.Messages() {
+				handler(context.Background(), handlers.Message{
+					Destination: m.Topic,
+					RoutingKey:  string(m.Key),
+					Payload:     m.Value,
+					Native:      m,
+				})
+			}
+		}(pc)
+	}
+	return nil
+}
+
+//Publish sends payload to the given Kafka topic.
+func (b *KafkaBroker) Publish(ctx context.Context, destination string, payload []byte) error {
+	_, _, err := b.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: destination,
+		Value: sarama.ByteEncoder(payload),
+	})
+	return err
+}
+
+//Ack is a no-op: sarama.PartitionConsumer has no offset-commit API of its
+//own, so at-least-once delivery with acknowledgement requires
+//sarama.ConsumerGroup instead.
+func (b *KafkaBroker) Ack(msg handlers.Message) error {
+	return nil
+}
+
+//Nack is a no-op for the same reason Ack is.
+func (b *KafkaBroker) Nack(msg handlers.Message) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+//Package broker provides handlers.MessageBroker implementations for the
+//queue/stream backends Orion's messaging subsystem is meant to support:
+//NATS, NATS Streaming, Kafka, and RabbitMQ.
+package broker
+
+import (
+	"context"
+
+	"github.com/carousell/Orion/orion/handlers"
+	nats "github.com/nats-io/nats.go"
+)
+
+//NATSBroker adapts a *nats.Conn to handlers.MessageBroker. Core NATS
+//publish/subscribe has no acknowledgement model, so Ack/Nack are no-ops.
+type NATSBroker struct {
+	conn *nats.Conn
+}
+
+//NewNATSBroker wraps conn as a handlers.MessageBroker.
+func NewNATSBroker(conn *nats.Conn) *NATSBroker {
+	return &NATSBroker{conn: conn}
+}
+
+//System reports "nats" as the messaging.system transaction attribute.
+func (b *NATSBroker) System() string {
+	return "nats"
+}
+
+//Subscribe registers handler on the given NATS subject.
+func (b *NATSBroker) Subscribe(destination string, handler func(ctx context.Context, msg handlers.Message)) error {
+	_, err := b.conn.Subscribe(destination, func(m *nats.Msg) {
+		handler(context.Background(), handlers.Message{
+			Destination: m.Subject,
+			Payload:     m.Data,
+			Native:      m,
+		})
+	})
+	return err
+}
+
+//Publish sends payload on the given NATS subject.
+func (b *NATSBroker) Publish(ctx context.Context, destination string, payload []byte) error {
+	return b.conn.Publish(destination, payload)
+}
+
+//Ack is a no-op: core NATS has no acknowledgement model.
+func (b *NATSBroker) Ack(msg handlers.Message) error {
+	return nil
+}
+
+//Nack is a no-op for the same reason Ack is.
+func (b *NATSBroker) Nack(msg handlers.Message) error {
+	return nil
+}
@@ -0,0 +1,68 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/carousell/Orion/orion/handlers"
+	amqp "github.com/streadway/amqp"
+)
+
+//RabbitMQBroker adapts an *amqp.Channel to handlers.MessageBroker, consuming
+//with manual acknowledgement so Ack/Nack map onto real delivery
+//acknowledgement and requeue.
+type RabbitMQBroker struct {
+	channel *amqp.Channel
+}
+
+//NewRabbitMQBroker wraps channel as a handlers.MessageBroker.
+func NewRabbitMQBroker(channel *amqp.Channel) *RabbitMQBroker {
+	return &RabbitMQBroker{channel: channel}
+}
+
+//System reports "rabbitmq" as the messaging.system transaction attribute.
+func (b *RabbitMQBroker) System() string {
+	return "rabbitmq"
+}
+
+//Subscribe consumes the given queue with manual acknowledgement, so the
+//caller controls Ack/Nack timing.
+func (b *RabbitMQBroker) Subscribe(destination string, handler func(ctx context.Context, msg handlers.Message)) error {
+	deliveries, err := b.channel.Consume(destination, "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for d := range deliveries {
+			handler(context.Background(), handlers.Message{
+				Destination: destination,
+				RoutingKey:  d.RoutingKey,
+				Payload:     d.Body,
+				Native:      d,
+			})
+		}
+	}()
+	return nil
+}
+
+//Publish sends payload to the given queue via the default exchange.
+func (b *RabbitMQBroker) Publish(ctx context.Context, destination string, payload []byte) error {
+	return b.channel.Publish("", destination, false, false, amqp.Publishing{Body: payload})
+}
+
+//Ack acknowledges msg, removing it from the queue.
+func (b *RabbitMQBroker) Ack(msg handlers.Message) error {
+	d, ok := msg.Native.(amqp.Delivery)
+	if !ok {
+		return nil
+	}
+	return d.Ack(false)
+}
+
+//Nack requeues msg for redelivery.
+func (b *RabbitMQBroker) Nack(msg handlers.Message) error {
+	d, ok := msg.Native.(amqp.Delivery)
+	if !ok {
+		return nil
+	}
+	return d.Nack(false, true)
+}
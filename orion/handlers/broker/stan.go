@@ -0,0 +1,60 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/carousell/Orion/orion/handlers"
+	stan "github.com/nats-io/stan.go"
+)
+
+//STANBroker adapts a stan.Conn (NATS Streaming) to handlers.MessageBroker,
+//using manual acknowledgement mode so Ack maps onto a real redelivery
+//guarantee.
+type STANBroker struct {
+	conn stan.Conn
+}
+
+//NewSTANBroker wraps conn as a handlers.MessageBroker.
+func NewSTANBroker(conn stan.Conn) *STANBroker {
+	return &STANBroker{conn: conn}
+}
+
+//System reports "nats_streaming" as the messaging.system transaction
+//attribute.
+func (b *STANBroker) System() string {
+	return "nats_streaming"
+}
+
+//Subscribe registers handler on the given NATS Streaming channel, in manual
+//ack mode so the caller controls Ack/Nack timing.
+func (b *STANBroker) Subscribe(destination string, handler func(ctx context.Context, msg handlers.Message)) error {
+	_, err := b.conn.Subscribe(destination, func(m *stan.Msg) {
+		handler(context.Background(), handlers.Message{
+			Destination: destination,
+			Payload:     m.Data,
+			Native:      m,
+		})
+	}, stan.SetManualAckMode())
+	return err
+}
+
+//Publish sends payload on the given NATS Streaming channel.
+func (b *STANBroker) Publish(ctx context.Context, destination string, payload []byte) error {
+	return b.conn.Publish(destination, payload)
+}
+
+//Ack acknowledges msg, preventing NATS Streaming from redelivering it.
+func (b *STANBroker) Ack(msg handlers.Message) error {
+	m, ok := msg.Native.(*stan.Msg)
+	if !ok {
+		return nil
+	}
+	return m.Ack()
+}
+
+//Nack lets msg redeliver by simply not acknowledging it; NATS Streaming has
+//no explicit negative-ack, so the subscription's ack-wait timeout drives
+//redelivery.
+func (b *STANBroker) Nack(msg handlers.Message) error {
+	return nil
+}
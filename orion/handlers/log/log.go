@@ -0,0 +1,61 @@
+//Package log provides structured loggers that decorate every log line with
+//the trace/span identifiers of the transaction found on the context, the
+//way New Relic's logrus/zap plugins do for direct users of the agent.
+//
+//This only takes effect once something has actually called
+//handlers.WithTraceMetadata on the request context (normally Orion's gRPC/
+//HTTP transport, via a handlers.Tracer). That transport wiring does not
+//exist in this tree yet (see the NOT YET WIRED IN note on handlers.Tracer),
+//so today traceFields finds no TraceMetadata and omits trace.id/span.id
+//entirely; the adapters here are not a complete, working feature on their
+//own until that wiring lands.
+package log
+
+import (
+	"context"
+	"os"
+
+	"github.com/carousell/Orion/orion/handlers"
+)
+
+var hostname, _ = os.Hostname()
+
+//EntityName and EntityType identify the Orion application in the emitted
+//log fields. SetEntity should be called once at startup, before any
+//request is served.
+var (
+	EntityName string
+	EntityType = "SERVICE"
+)
+
+//SetEntity records the application name used to decorate log lines with
+//entity.name/entity.type/entity.guid.
+func SetEntity(name, guid string) {
+	EntityName = name
+	entityGUID = guid
+}
+
+var entityGUID string
+
+//traceFields builds the trace/entity/hostname portion of the stable log
+//schema described in logrus.go/zap.go: {message, log.level, timestamp,
+//trace.id, span.id, entity.guid, entity.name, entity.type, hostname}.
+//message/log.level/timestamp come from the formatter configuration those
+//adapters set up; traceFields only supplies the rest.
+func traceFields(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{
+		"hostname": hostname,
+	}
+	if EntityName != "" {
+		f["entity.name"] = EntityName
+		f["entity.type"] = EntityType
+		f["entity.guid"] = entityGUID
+	}
+	md, ok := handlers.TraceMetadataFromContext(ctx)
+	if !ok {
+		return f
+	}
+	f["trace.id"] = md.TraceID
+	f["span.id"] = md.SpanID
+	return f
+}
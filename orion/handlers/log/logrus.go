@@ -0,0 +1,56 @@
+package log
+
+import (
+	"context"
+
+	"github.com/carousell/Orion/orion/handlers"
+	"github.com/sirupsen/logrus"
+)
+
+//logrusFieldMap remaps logrus's default msg/level/time keys to Orion's
+//stable log schema: {message, log.level, timestamp, trace.id, span.id,
+//entity.guid, entity.name, entity.type, hostname}.
+var logrusFieldMap = logrus.FieldMap{
+	logrus.FieldKeyMsg:   "message",
+	logrus.FieldKeyLevel: "log.level",
+	logrus.FieldKeyTime:  "timestamp",
+}
+
+//LogrusLogger adapts a *logrus.Logger to handlers.ContextLogger, attaching
+//trace/span fields to every entry emitted through WithContext.
+type LogrusLogger struct {
+	*logrus.Logger
+}
+
+//NewLogrusLogger wraps logger as a handlers.ContextLogger, configuring its
+//formatter to emit Orion's stable log schema.
+func NewLogrusLogger(logger *logrus.Logger) *LogrusLogger {
+	logger.Formatter = &logrus.JSONFormatter{FieldMap: logrusFieldMap}
+	return &LogrusLogger{Logger: logger}
+}
+
+//WithContext returns a Logger that decorates every entry with the trace
+//metadata found on ctx, or none if ctx carries no transaction.
+func (l *LogrusLogger) WithContext(ctx context.Context) handlers.Logger {
+	return &logrusEntry{entry: l.Logger.WithFields(traceFields(ctx))}
+}
+
+type logrusEntry struct {
+	entry *logrus.Entry
+}
+
+func (l *logrusEntry) Debug(msg string, fields map[string]interface{}) {
+	l.entry.WithFields(fields).Debug(msg)
+}
+
+func (l *logrusEntry) Info(msg string, fields map[string]interface{}) {
+	l.entry.WithFields(fields).Info(msg)
+}
+
+func (l *logrusEntry) Warn(msg string, fields map[string]interface{}) {
+	l.entry.WithFields(fields).Warn(msg)
+}
+
+func (l *logrusEntry) Error(msg string, fields map[string]interface{}) {
+	l.entry.WithFields(fields).Error(msg)
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+
+	"github.com/carousell/Orion/orion/handlers"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+//NewZapEncoderConfig returns the zapcore.EncoderConfig callers must build
+//their *zap.Logger with before passing it to NewZapLogger, so that the
+//message/log.level/timestamp keys match Orion's stable log schema.
+func NewZapEncoderConfig() zapcore.EncoderConfig {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.MessageKey = "message"
+	cfg.LevelKey = "log.level"
+	cfg.TimeKey = "timestamp"
+	return cfg
+}
+
+//ZapLogger adapts a *zap.Logger to handlers.ContextLogger, attaching
+//trace/span fields as structured zap fields on every entry emitted through
+//WithContext. logger must be built with NewZapEncoderConfig for the emitted
+//keys to match Orion's stable log schema.
+type ZapLogger struct {
+	logger *zap.Logger
+}
+
+//NewZapLogger wraps logger as a handlers.ContextLogger.
+func NewZapLogger(logger *zap.Logger) *ZapLogger {
+	return &ZapLogger{logger: logger}
+}
+
+//WithContext returns a Logger that decorates every entry with the trace
+//metadata found on ctx, or none if ctx carries no transaction.
+func (z *ZapLogger) WithContext(ctx context.Context) handlers.Logger {
+	return &zapEntry{logger: z.logger.With(toZapFields(traceFields(ctx))...)}
+}
+
+type zapEntry struct {
+	logger *zap.Logger
+}
+
+func toZapFields(fields map[string]interface{}) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		zapFields = append(zapFields, zap.Any(k, v))
+	}
+	return zapFields
+}
+
+func (z *zapEntry) Debug(msg string, fields map[string]interface{}) {
+	z.logger.Debug(msg, toZapFields(fields)...)
+}
+
+func (z *zapEntry) Info(msg string, fields map[string]interface{}) {
+	z.logger.Info(msg, toZapFields(fields)...)
+}
+
+func (z *zapEntry) Warn(msg string, fields map[string]interface{}) {
+	z.logger.Warn(msg, toZapFields(fields)...)
+}
+
+func (z *zapEntry) Error(msg string, fields map[string]interface{}) {
+	z.logger.Error(msg, toZapFields(fields)...)
+}
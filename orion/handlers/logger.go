@@ -0,0 +1,19 @@
+package handlers
+
+import "context"
+
+//Logger is the minimal logging surface ContextLogger adapters expose.
+type Logger interface {
+	Debug(msg string, fields map[string]interface{})
+	Info(msg string, fields map[string]interface{})
+	Warn(msg string, fields map[string]interface{})
+	Error(msg string, fields map[string]interface{})
+}
+
+//ContextLogger returns a Logger decorated with the trace metadata found on
+//ctx. WithContext must be safe to call when ctx carries no transaction; in
+//that case the trace/span fields are simply omitted. orion/handlers/log
+//provides logrus and zap adapters implementing this interface.
+type ContextLogger interface {
+	WithContext(ctx context.Context) Logger
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import "context"
+
+//MessageBroker abstracts the queue/stream backend (NATS, NATS Streaming,
+//Kafka, RabbitMQ, ...) behind Subscribe/Publish so that
+//RegisterMessageHandler and RegisterMessagePublisher do not need to know
+//which broker a service is wired to.
+type MessageBroker interface {
+	//Subscribe registers handler to be invoked for every message delivered
+	//on destination. handler is responsible for calling Ack/Nack on msg.
+	Subscribe(destination string, handler func(ctx context.Context, msg Message)) error
+
+	//Publish sends payload to destination.
+	Publish(ctx context.Context, destination string, payload []byte) error
+
+	//Ack acknowledges successful processing of msg.
+	Ack(msg Message) error
+
+	//Nack signals that msg was not processed successfully and should be
+	//redelivered or dead-lettered, depending on the broker's policy.
+	Nack(msg Message) error
+}
+
+//Message is a single delivery received from a MessageBroker.
+type Message struct {
+	Destination string
+	RoutingKey  string
+	Payload     []byte
+
+	//Native is the broker-specific delivery (e.g. *stan.Msg, amqp.Delivery)
+	//that a MessageBroker implementation's Ack/Nack methods use to
+	//acknowledge it. Callers outside the broker implementation should not
+	//depend on its concrete type.
+	Native interface{}
+}
+
+//MessageDecoder unmarshals a broker payload into the request type expected
+//by the registered service method.
+type MessageDecoder func(ctx context.Context, payload []byte) (interface{}, error)
+
+//MessageHandleable is implemented by Orion servers that support registering
+//message consumers/producers, mirroring Encodeable for HTTP.
+type MessageHandleable interface {
+	//AddMessageHandler registers method on serviceName to be invoked for
+	//every message broker delivers on topic.
+	AddMessageHandler(serviceName, method string, broker MessageBroker, topic string, decoder MessageDecoder)
+
+	//AddMessagePublisher registers method on serviceName as a publisher of
+	//its response to destination on broker.
+	AddMessagePublisher(serviceName, method string, broker MessageBroker, destination string)
+}
+
+//MessageSystem is implemented by MessageBroker backends that want to report
+//their broker name (e.g. "kafka", "nats") as the messaging.system transaction
+//attribute. Brokers that don't implement it are tagged "unknown".
+type MessageSystem interface {
+	System() string
+}
+
+type messagingAttributesKey struct{}
+
+//WithMessagingAttributes returns a copy of ctx carrying attrs, retrievable
+//via MessagingAttributesFromContext.
+func WithMessagingAttributes(ctx context.Context, attrs map[string]interface{}) context.Context {
+	return context.WithValue(ctx, messagingAttributesKey{}, attrs)
+}
+
+//MessagingAttributesFromContext returns the messaging.* transaction
+//attributes stashed on ctx by RegisterMessageHandler, if any.
+func MessagingAttributesFromContext(ctx context.Context) (map[string]interface{}, bool) {
+	attrs, ok := ctx.Value(messagingAttributesKey{}).(map[string]interface{})
+	return attrs, ok
+}
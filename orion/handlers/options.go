@@ -0,0 +1,68 @@
+package handlers
+
+//ErrorClass describes how an error/status code pair should be accounted for
+//by the HTTP handler when it decides whether a response contributes to the
+//error rate.
+type ErrorClass int
+
+const (
+	//ErrorClassNone means the response is not an error at all.
+	ErrorClassNone ErrorClass = iota
+	//ErrorClassExpected means the response is an error, but an expected
+	//one: it is still traced but does not count against Apdex/error
+	//budgets.
+	ErrorClassExpected
+	//ErrorClassUnexpected means the response counts against the error
+	//rate as usual.
+	ErrorClassUnexpected
+)
+
+//ErrorClassifier decides the ErrorClass for a given error/status code pair.
+//err is nil when the handler only has a non-2xx status code to go on.
+type ErrorClassifier func(err error, statusCode int) ErrorClass
+
+//EncoderOptions configures the per-route behavior of a registered HTTP
+//encoder beyond the encoder function itself.
+type EncoderOptions struct {
+	//ExpectStatusCodes marks these status codes as expected: they are
+	//still traced, but do not count against Apdex/error budgets.
+	ExpectStatusCodes []int
+	//IgnoreStatusCodes marks these status codes as not errors at all.
+	IgnoreStatusCodes []int
+	//ErrorClassifier, when set, takes precedence over
+	//ExpectStatusCodes/IgnoreStatusCodes for deciding a response's
+	//ErrorClass.
+	ErrorClassifier ErrorClassifier
+}
+
+//Classify applies o's ErrorClassifier if set, otherwise falls back to
+//ExpectStatusCodes/IgnoreStatusCodes.
+func (o EncoderOptions) Classify(err error, statusCode int) ErrorClass {
+	if o.ErrorClassifier != nil {
+		return o.ErrorClassifier(err, statusCode)
+	}
+	for _, code := range o.IgnoreStatusCodes {
+		if code == statusCode {
+			return ErrorClassNone
+		}
+	}
+	for _, code := range o.ExpectStatusCodes {
+		if code == statusCode {
+			return ErrorClassExpected
+		}
+	}
+	if err != nil || statusCode >= 400 {
+		return ErrorClassUnexpected
+	}
+	return ErrorClassNone
+}
+
+//EncodeableWithOptions is implemented by Orion servers that support
+//EncoderOptions alongside the plain Encodeable registration.
+type EncodeableWithOptions interface {
+	Encodeable
+	//AddEncoderWithOptions registers encoder the same way
+	//Encodeable.AddEncoder does, additionally applying opts when the HTTP
+	//handler decides how to classify the response.
+	AddEncoderWithOptions(serviceName, method, httpMethod, path string, encoder Encoder, opts EncoderOptions)
+}
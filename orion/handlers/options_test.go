@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncoderOptionsClassifyDefaults(t *testing.T) {
+	var o EncoderOptions
+	if got := o.Classify(nil, 200); got != ErrorClassNone {
+		t.Errorf("Classify(nil, 200) = %v, want ErrorClassNone", got)
+	}
+	if got := o.Classify(nil, 500); got != ErrorClassUnexpected {
+		t.Errorf("Classify(nil, 500) = %v, want ErrorClassUnexpected", got)
+	}
+	if got := o.Classify(errors.New("boom"), 200); got != ErrorClassUnexpected {
+		t.Errorf("Classify(err, 200) = %v, want ErrorClassUnexpected", got)
+	}
+}
+
+func TestEncoderOptionsClassifyIgnoreAndExpect(t *testing.T) {
+	o := EncoderOptions{
+		ExpectStatusCodes: []int{409},
+		IgnoreStatusCodes: []int{404},
+	}
+	if got := o.Classify(nil, 404); got != ErrorClassNone {
+		t.Errorf("Classify(nil, 404) = %v, want ErrorClassNone", got)
+	}
+	if got := o.Classify(nil, 409); got != ErrorClassExpected {
+		t.Errorf("Classify(nil, 409) = %v, want ErrorClassExpected", got)
+	}
+	if got := o.Classify(nil, 500); got != ErrorClassUnexpected {
+		t.Errorf("Classify(nil, 500) = %v, want ErrorClassUnexpected", got)
+	}
+}
+
+func TestEncoderOptionsClassifyIgnoreTakesPrecedenceOverExpect(t *testing.T) {
+	o := EncoderOptions{
+		ExpectStatusCodes: []int{404},
+		IgnoreStatusCodes: []int{404},
+	}
+	if got := o.Classify(nil, 404); got != ErrorClassNone {
+		t.Errorf("Classify(nil, 404) = %v, want ErrorClassNone when a code is in both lists", got)
+	}
+}
+
+func TestEncoderOptionsClassifyErrorClassifierTakesPrecedence(t *testing.T) {
+	o := EncoderOptions{
+		IgnoreStatusCodes: []int{404},
+		ErrorClassifier: func(err error, statusCode int) ErrorClass {
+			return ErrorClassExpected
+		},
+	}
+	if got := o.Classify(nil, 404); got != ErrorClassExpected {
+		t.Errorf("Classify(nil, 404) = %v, want ErrorClassExpected from ErrorClassifier", got)
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//HeaderTraceParent and HeaderTraceState are the W3C Trace Context headers.
+//HeaderNewRelic is the legacy New Relic distributed trace payload header,
+//consulted when no W3C headers are present.
+const (
+	HeaderTraceParent = "traceparent"
+	HeaderTraceState  = "tracestate"
+	HeaderNewRelic    = "newrelic"
+)
+
+const traceParentVersion = "00"
+
+var (
+	errTraceParentFormat  = errors.New("handlers: malformed traceparent header")
+	errTraceParentVersion = errors.New("handlers: unsupported traceparent version")
+)
+
+//sampledFlag is the "sampled" bit of the traceparent flags byte, as defined
+//by the W3C Trace Context spec.
+const sampledFlag = 0x1
+
+//ParseTraceParent parses a `traceparent` header of the form
+//`version-traceID(16B)-spanID(8B)-flags(1B)` and returns the TraceMetadata it
+//describes. The sampling decision is taken from the low bit of the flags
+//byte so that it can be inherited by child spans.
+func ParseTraceParent(header string) (TraceMetadata, error) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 {
+		return TraceMetadata{}, errTraceParentFormat
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if version != traceParentVersion {
+		return TraceMetadata{}, errTraceParentVersion
+	}
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return TraceMetadata{}, errTraceParentFormat
+	}
+	flagByte, err := hex.DecodeString(flags)
+	if err != nil {
+		return TraceMetadata{}, errTraceParentFormat
+	}
+	if _, err := hex.DecodeString(traceID); err != nil {
+		return TraceMetadata{}, errTraceParentFormat
+	}
+	if _, err := hex.DecodeString(spanID); err != nil {
+		return TraceMetadata{}, errTraceParentFormat
+	}
+	return TraceMetadata{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Sampled: flagByte[0]&sampledFlag != 0,
+	}, nil
+}
+
+//BuildTraceParent renders md as a `traceparent` header, generating a fresh
+//8-byte span ID for the outbound segment while keeping md's trace ID and
+//sampling decision intact.
+func BuildTraceParent(md TraceMetadata) (string, error) {
+	spanID, err := NewSpanID()
+	if err != nil {
+		return "", err
+	}
+	flags := byte(0)
+	if md.Sampled {
+		flags |= sampledFlag
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, md.TraceID, spanID, flags), nil
+}
+
+//NewTraceID generates a random 16-byte trace ID, hex encoded.
+func NewTraceID() (string, error) {
+	return randomHex(16)
+}
+
+//NewSpanID generates a random 8-byte span ID, hex encoded.
+func NewSpanID() (string, error) {
+	return randomHex(8)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import "testing"
+
+func TestParseTraceParent(t *testing.T) {
+	md, err := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	if err != nil {
+		t.Fatalf("ParseTraceParent() error = %v", err)
+	}
+	if md.TraceID != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("TraceID = %q", md.TraceID)
+	}
+	if md.SpanID != "b7ad6b7169203331" {
+		t.Errorf("SpanID = %q", md.SpanID)
+	}
+	if !md.Sampled {
+		t.Errorf("Sampled = false, want true")
+	}
+}
+
+func TestParseTraceParentNotSampled(t *testing.T) {
+	md, err := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+	if err != nil {
+		t.Fatalf("ParseTraceParent() error = %v", err)
+	}
+	if md.Sampled {
+		t.Errorf("Sampled = true, want false")
+	}
+}
+
+func TestParseTraceParentErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"00-bad-b7ad6b7169203331-01",
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"00-0af7651916cd43dd8448eb211c80319c-short-01",
+	}
+	for _, header := range cases {
+		if _, err := ParseTraceParent(header); err == nil {
+			t.Errorf("ParseTraceParent(%q) error = nil, want error", header)
+		}
+	}
+}
+
+func TestBuildTraceParentRoundTrip(t *testing.T) {
+	in := TraceMetadata{TraceID: "0af7651916cd43dd8448eb211c80319c", Sampled: true}
+	header, err := BuildTraceParent(in)
+	if err != nil {
+		t.Fatalf("BuildTraceParent() error = %v", err)
+	}
+
+	out, err := ParseTraceParent(header)
+	if err != nil {
+		t.Fatalf("ParseTraceParent(%q) error = %v", header, err)
+	}
+	if out.TraceID != in.TraceID {
+		t.Errorf("TraceID = %q, want %q", out.TraceID, in.TraceID)
+	}
+	if !out.Sampled {
+		t.Errorf("Sampled = false, want true")
+	}
+	if out.SpanID == "" {
+		t.Errorf("SpanID is empty")
+	}
+}
+
+func TestNewSpanIDUnique(t *testing.T) {
+	a, err := NewSpanID()
+	if err != nil {
+		t.Fatalf("NewSpanID() error = %v", err)
+	}
+	b, err := NewSpanID()
+	if err != nil {
+		t.Fatalf("NewSpanID() error = %v", err)
+	}
+	if len(a) != 16 || len(b) != 16 {
+		t.Fatalf("NewSpanID() = %q, %q, want 16 hex chars each", a, b)
+	}
+	if a == b {
+		t.Errorf("NewSpanID() returned the same value twice: %q", a)
+	}
+}
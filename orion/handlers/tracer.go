@@ -0,0 +1,67 @@
+package handlers
+
+import "context"
+
+//Tracer is the intended extension point for distributed tracing backends
+//(New Relic DT, Jaeger, OTel, ...): once wired in, Orion's gRPC and HTTP
+//transports would call into a Tracer to start transactions from an inbound
+//request and to propagate the trace context on outbound calls made through
+//Orion's client helpers.
+//
+//NOT YET WIRED IN: this tree has no gRPC server interceptors and no HTTP
+//handler/encoder dispatch path for a Tracer to plug into (RegisterEncoder in
+//orion/utils.go only registers a route; nothing in this tree serves one), so
+//StartTransaction, AcceptDistributedTracePayload and CreateDistributedTrace-
+//Payload are defined but currently called from nowhere. What ships here is
+//the wire-format primitives (Tracer/TraceMetadata types, ParseTraceParent/
+//BuildTraceParent/ParseTraceState/BuildTraceState) that a Tracer implementation
+//and the transport layer will share; the interceptor/handler wiring itself is
+//unimplemented and should be tracked as separate follow-up work, not assumed
+//to exist.
+type Tracer interface {
+	//StartTransaction begins a new transaction/segment for name, using the
+	//parent trace metadata extracted from the inbound request, if any.
+	StartTransaction(ctx context.Context, name string, parent *TraceMetadata) context.Context
+
+	//AcceptDistributedTracePayload parses an inbound trace context (the
+	//W3C `traceparent`/`tracestate` pair or a New Relic `newrelic` payload)
+	//and returns the TraceMetadata it describes.
+	AcceptDistributedTracePayload(headerType, payload string) (*TraceMetadata, error)
+
+	//CreateDistributedTracePayload returns the TraceMetadata of the
+	//transaction on ctx for a downstream call; BuildTraceParent and
+	//BuildTraceState render the headers to inject from it.
+	CreateDistributedTracePayload(ctx context.Context) (TraceMetadata, error)
+
+	//GetLinkingMetadata returns the trace/span identifiers and entity
+	//information for the transaction on ctx, for use by logging and other
+	//side channels that need to correlate with the current trace.
+	GetLinkingMetadata(ctx context.Context) TraceMetadata
+}
+
+//TraceMetadata carries the identifiers needed to correlate a request across
+//services and with out-of-band signals such as logs.
+type TraceMetadata struct {
+	TraceID    string
+	SpanID     string
+	Sampled    bool
+	EntityName string
+	EntityType string
+	EntityGUID string
+}
+
+type traceMetadataKey struct{}
+
+//WithTraceMetadata returns a copy of ctx carrying md, retrievable via
+//TraceMetadataFromContext.
+func WithTraceMetadata(ctx context.Context, md TraceMetadata) context.Context {
+	return context.WithValue(ctx, traceMetadataKey{}, md)
+}
+
+//TraceMetadataFromContext returns the TraceMetadata stashed on ctx, if any.
+//ok is false when ctx carries no transaction, which callers must treat as a
+//normal, unsampled request rather than an error.
+func TraceMetadataFromContext(ctx context.Context) (md TraceMetadata, ok bool) {
+	md, ok = ctx.Value(traceMetadataKey{}).(TraceMetadata)
+	return md, ok
+}
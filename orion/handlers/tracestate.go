@@ -0,0 +1,51 @@
+package handlers
+
+import "strings"
+
+//traceStateVendorKey is the key Orion writes its own entry under in the W3C
+//`tracestate` header.
+const traceStateVendorKey = "newrelic"
+
+//TraceStateEntry is a single `vendor=value` member of a `tracestate` header.
+//Order matters: per the W3C Trace Context spec, the first entry is the most
+//recently written one.
+type TraceStateEntry struct {
+	Key   string
+	Value string
+}
+
+//ParseTraceState parses a `tracestate` header into its ordered list of
+//vendor entries. Malformed members (missing `=`) are skipped rather than
+//failing the whole header, per the spec's guidance to be lenient on read.
+func ParseTraceState(header string) []TraceStateEntry {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil
+	}
+	members := strings.Split(header, ",")
+	entries := make([]TraceStateEntry, 0, len(members))
+	for _, m := range members {
+		kv := strings.SplitN(strings.TrimSpace(m), "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			continue
+		}
+		entries = append(entries, TraceStateEntry{Key: strings.TrimSpace(kv[0]), Value: strings.TrimSpace(kv[1])})
+	}
+	return entries
+}
+
+//BuildTraceState renders an updated `tracestate` header for an outbound
+//call: it writes/moves Orion's own entry (vendorValue) to the front and
+//carries the remaining entries from existing through unchanged, as the spec
+//requires when a participant mutates the trace state it received.
+func BuildTraceState(existing []TraceStateEntry, vendorValue string) string {
+	members := make([]string, 0, len(existing)+1)
+	members = append(members, traceStateVendorKey+"="+vendorValue)
+	for _, e := range existing {
+		if e.Key == traceStateVendorKey {
+			continue
+		}
+		members = append(members, e.Key+"="+e.Value)
+	}
+	return strings.Join(members, ",")
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import "testing"
+
+func TestParseTraceState(t *testing.T) {
+	entries := ParseTraceState("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,malformed")
+	want := []TraceStateEntry{
+		{Key: "rojo", Value: "00f067aa0ba902b7"},
+		{Key: "congo", Value: "t61rcWkgMzE"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("ParseTraceState() = %v, want %v", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestParseTraceStateEmpty(t *testing.T) {
+	if entries := ParseTraceState(""); entries != nil {
+		t.Errorf("ParseTraceState(\"\") = %v, want nil", entries)
+	}
+}
+
+func TestBuildTraceStateMovesOwnEntryToFront(t *testing.T) {
+	existing := ParseTraceState("newrelic=old-value,rojo=00f067aa0ba902b7")
+	header := BuildTraceState(existing, "new-value")
+
+	got := ParseTraceState(header)
+	want := []TraceStateEntry{
+		{Key: "newrelic", Value: "new-value"},
+		{Key: "rojo", Value: "00f067aa0ba902b7"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("BuildTraceState() round-tripped to %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
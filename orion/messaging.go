@@ -0,0 +1,84 @@
+package orion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carousell/Orion/orion/handlers"
+)
+
+//Messaging attribute names added to the transaction for message consumers
+//and publishers, following the same naming used for HTTP/gRPC attributes.
+const (
+	AttributeMessagingSystem          = "messaging.system"
+	AttributeMessagingDestination     = "messaging.destination"
+	AttributeMessagingDestinationKind = "messaging.destination_kind"
+	AttributeMessagingRoutingKey      = "messaging.routing_key"
+	AttributeMessagingOperation       = "messaging.operation"
+
+	MessagingOperationReceive = "receive"
+)
+
+//destinationKind is the messaging.destination_kind value Orion reports for
+//every broker registered through RegisterMessageHandler/
+//RegisterMessagePublisher. It is "topic" for all of them, including
+//RabbitMQBroker, whose destinations are queues.
+const destinationKind = "topic"
+
+//messagingAttributes builds the messaging.* transaction attributes for a
+//delivery on destination/routingKey, tagging messaging.operation=receive for
+//consumers as New Relic's messaging semantic conventions expect.
+func messagingAttributes(broker handlers.MessageBroker, destination, routingKey string, consumer bool) map[string]interface{} {
+	system := "unknown"
+	if s, ok := broker.(handlers.MessageSystem); ok {
+		system = s.System()
+	}
+	attrs := map[string]interface{}{
+		AttributeMessagingSystem:          system,
+		AttributeMessagingDestination:     destination,
+		AttributeMessagingDestinationKind: destinationKind,
+	}
+	if routingKey != "" {
+		attrs[AttributeMessagingRoutingKey] = routingKey
+	}
+	if consumer {
+		attrs[AttributeMessagingOperation] = MessagingOperationReceive
+	}
+	return attrs
+}
+
+//RegisterMessageHandler allows for registering a service method as a message
+//consumer on broker/topic, the same way RegisterEncoder registers an HTTP
+//route: this call only records the registration on svr, it does not itself
+//call broker.Subscribe or invoke method. Starting the subscription, running
+//method inside a managed transaction, and applying tracing/metrics/
+//middleware is the responsibility of the concrete Server implementation at
+//dispatch time, exactly as it is for HTTP/gRPC. What RegisterMessageHandler
+//adds on top of a plain registration is: it wraps decoder so the dispatched
+//context carries the messaging.* attributes built by messagingAttributes,
+//and it recovers a panic from decoder so a single malformed payload can't
+//crash the goroutine the Server implementation runs it on.
+//Note: this is normally called from protoc-gen-orion autogenerated files
+func RegisterMessageHandler(svr Server, serviceName, method string, broker handlers.MessageBroker, topic string, decoder handlers.MessageDecoder) {
+	tagged := func(ctx context.Context, payload []byte) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("orion: panic in message handler %s.%s: %v", serviceName, method, r)
+			}
+		}()
+		attrs := messagingAttributes(broker, topic, "", true)
+		return decoder(handlers.WithMessagingAttributes(ctx, attrs), payload)
+	}
+	if m, ok := svr.(handlers.MessageHandleable); ok {
+		m.AddMessageHandler(serviceName, method, broker, topic, tagged)
+	}
+}
+
+//RegisterMessagePublisher allows for registering a service method's response
+//to be published to broker/destination once the method returns.
+//Note: this is normally called from protoc-gen-orion autogenerated files
+func RegisterMessagePublisher(svr Server, serviceName, method string, broker handlers.MessageBroker, destination string) {
+	if m, ok := svr.(handlers.MessageHandleable); ok {
+		m.AddMessagePublisher(serviceName, method, broker, destination)
+	}
+}
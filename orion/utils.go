@@ -7,6 +7,20 @@ import (
 //RegisterEncoder allows for registering an HTTP request encoder to arbitrary urls
 //Note: this is normally called from protoc-gen-orion autogenerated files
 func RegisterEncoder(svr Server, serviceName, method, httpMethod, path string, encoder handlers.Encoder) {
+	RegisterEncoderWithOptions(svr, serviceName, method, httpMethod, path, encoder, handlers.EncoderOptions{})
+}
+
+//RegisterEncoderWithOptions is like RegisterEncoder but additionally lets
+//callers declare per-route error semantics via opts, e.g. marking a 404 on
+//`GET /users/{id}` as expected while a 409 on `POST /users` is expected for
+//a different route. svr must implement handlers.EncodeableWithOptions for
+//opts to take effect; otherwise this falls back to plain registration.
+//Note: this is normally called from protoc-gen-orion autogenerated files
+func RegisterEncoderWithOptions(svr Server, serviceName, method, httpMethod, path string, encoder handlers.Encoder, opts handlers.EncoderOptions) {
+	if e, ok := svr.(handlers.EncodeableWithOptions); ok {
+		e.AddEncoderWithOptions(serviceName, method, httpMethod, path, encoder, opts)
+		return
+	}
 	if e, ok := svr.(handlers.Encodeable); ok {
 		e.AddEncoder(serviceName, method, httpMethod, path, encoder)
 	}